@@ -2,6 +2,28 @@ package nut
 
 import "errors"
 
+// ErrTLSNotConfigured is returned when a STARTTLS request is refused because upsd does not support or has not
+// been configured for TLS/SSL mode. It corresponds to the NUT "FEATURE-NOT-SUPPORTED" and "FEATURE-NOT-CONFIGURED"
+// error codes.
+var ErrTLSNotConfigured = errors.New("this instance of upsd does not support or has not been configured for STARTTLS")
+
+// ErrTLSAlreadyEnabled is returned when a STARTTLS request is sent on a connection that has already been
+// upgraded to TLS/SSL mode. It corresponds to the NUT "ALREADY-SSL-MODE" error code.
+var ErrTLSAlreadyEnabled = errors.New("TLS/SSL mode is already enabled on this connection")
+
+// ErrTLSRequired is returned by SendCommandContext when the Client was configured with RequireTLS and the caller
+// attempts to send USERNAME or PASSWORD before the connection has been upgraded via STARTTLS.
+var ErrTLSRequired = errors.New("TLS is required on this client but the connection has not been upgraded via STARTTLS")
+
+// ErrAlreadyLoggedIn is returned by UPS.Login when this connection has already sent LOGIN for a UPS. upsd
+// allows at most one LOGIN record per connection. It corresponds to the NUT "ALREADY-LOGGED-IN" error code.
+var ErrAlreadyLoggedIn = errors.New("this client has already sent LOGIN for a UPS on this connection")
+
+// ErrLogoutEndsConnection is returned by UPS.Logout when another UPS is still logged in on the same Client.
+// NUT's LOGOUT command is scoped to the whole connection, not to a single UPS, so honoring it here would log
+// the other UPS out too; Logout refuses rather than doing that silently.
+var ErrLogoutEndsConnection = errors.New("LOGOUT would end the whole connection, but another UPS is still logged in on it")
+
 // errorForMessage returns an error for the specified NUT error code.
 func errorForMessage(message string) (err error) {
 	switch message {
@@ -23,18 +45,16 @@ func errorForMessage(message string) (err error) {
 		err = errors.New("The requested variable in a SET command is not writable")
 	case "TOO-LONG":
 		err = errors.New("The requested value in a SET command is too long")
-	case "FEATURE-NOT-SUPPORTED":
-		err = errors.New("This instance of upsd does not support the requested feature. This is only used for TLS/SSL mode (STARTTLS) at the moment")
-	case "FEATURE-NOT-CONFIGURED":
-		err = errors.New("This instance of upsd hasn’t been configured properly to allow the requested feature to operate. This is also limited to STARTTLS for now")
+	case "FEATURE-NOT-SUPPORTED", "FEATURE-NOT-CONFIGURED":
+		err = ErrTLSNotConfigured
 	case "ALREADY-SSL-MODE":
-		err = errors.New("TLS/SSL mode is already enabled on this connection, so upsd can’t start it again")
+		err = ErrTLSAlreadyEnabled
 	case "DRIVER-NOT-CONNECTED":
 		err = errors.New("upsd can’t perform the requested command, since the driver for that UPS is not connected. This usually means that the driver is not running, or if it is, the ups.conf is misconfigured")
 	case "DATA-STALE":
 		err = errors.New("upsd is connected to the driver for the UPS, but that driver isn’t providing regular updates or has specifically marked the data as stale. upsd refuses to provide variables on stale units to avoid false readings. This generally means that the driver is running, but it has lost communications with the hardware. Check the physical connection to the equipment")
 	case "ALREADY-LOGGED-IN":
-		err = errors.New("The client already sent LOGIN for a UPS and can’t do it again. There is presently a limit of one LOGIN record per connection")
+		err = ErrAlreadyLoggedIn
 	case "INVALID-PASSWORD":
 		err = errors.New("The client sent an invalid PASSWORD - perhaps an empty one")
 	case "ALREADY-SET-PASSWORD":
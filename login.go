@@ -0,0 +1,124 @@
+package nut
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// protocolVersionPrimarySupport is the lowest NUT network protocol version (NETVER) that accepts the PRIMARY
+// verb in place of the legacy MASTER one. PRIMARY was introduced alongside NUT 2.8.0; see docs/net-protocol.txt.
+const protocolVersionPrimarySupport = "1.3"
+
+// primaryCommandVerb returns "PRIMARY" for upsd instances new enough to support it, falling back to the legacy
+// "MASTER" verb otherwise.
+func (c *Client) primaryCommandVerb() string {
+	if compareDottedVersions(c.ProtocolVersion, protocolVersionPrimarySupport) >= 0 {
+		return "PRIMARY"
+	}
+	return "MASTER"
+}
+
+// compareDottedVersions compares two dotted version strings (e.g. "1.3") component-wise, returning -1, 0 or 1
+// as a is less than, equal to, or greater than b. Unparseable or missing components are treated as 0.
+func compareDottedVersions(a, b string) int {
+	aParts := strings.Split(a, ".")
+	bParts := strings.Split(b, ".")
+
+	for i := 0; i < len(aParts) || i < len(bParts); i++ {
+		var x, y int
+		if i < len(aParts) {
+			x, _ = strconv.Atoi(aParts[i])
+		}
+		if i < len(bParts) {
+			y, _ = strconv.Atoi(bParts[i])
+		}
+		if x != y {
+			if x < y {
+				return -1
+			}
+			return 1
+		}
+	}
+
+	return 0
+}
+
+// addLoggedIn records that name has been logged in on this connection.
+func (c *Client) addLoggedIn(name string) {
+	c.loggedInMu.Lock()
+	defer c.loggedInMu.Unlock()
+	c.loggedIn[name] = true
+}
+
+// removeLoggedIn forgets that name has been logged in on this connection.
+func (c *Client) removeLoggedIn(name string) {
+	c.loggedInMu.Lock()
+	defer c.loggedInMu.Unlock()
+	delete(c.loggedIn, name)
+}
+
+// hasOtherLoggedIn reports whether a UPS other than name is currently tracked as logged in on this connection.
+func (c *Client) hasOtherLoggedIn(name string) bool {
+	c.loggedInMu.Lock()
+	defer c.loggedInMu.Unlock()
+	for loggedInName := range c.loggedIn {
+		if loggedInName != name {
+			return true
+		}
+	}
+	return false
+}
+
+// Login sends LOGIN for this UPS, registering this session with upsd so that it is counted by
+// UPS.GetNumberOfLogins. The session must already be authenticated via Client.Authenticate.
+//
+// If this connection has already logged in for a UPS, upsd refuses a second one; that failure is returned as
+// ErrAlreadyLoggedIn.
+func (u *UPS) Login() error {
+	return u.LoginContext(context.Background())
+}
+
+// LoginContext sends LOGIN for this UPS, honoring ctx. See Login.
+func (u *UPS) LoginContext(ctx context.Context) error {
+	resp, err := u.nutClient.SendCommandContext(ctx, fmt.Sprintf("LOGIN %s", u.Name))
+	if err != nil {
+		return err
+	}
+	if resp[0] != "OK" {
+		return fmt.Errorf("unexpected LOGIN response: %s", resp[0])
+	}
+
+	u.nutClient.addLoggedIn(u.Name)
+
+	return nil
+}
+
+// Logout releases the session Login registered for this UPS. NUT only tracks a single LOGIN record per
+// connection, so LOGOUT always ends the whole connection's session, not just this UPS's. If another UPS on this
+// same Client is still logged in, Logout refuses to send LOGOUT rather than silently logging that UPS out too,
+// and returns ErrLogoutEndsConnection; log out every other UPS (or call Client.Disconnect) first.
+func (u *UPS) Logout() error {
+	return u.LogoutContext(context.Background())
+}
+
+// LogoutContext releases the session Login registered for this UPS, honoring ctx. See Logout.
+func (u *UPS) LogoutContext(ctx context.Context) error {
+	if u.nutClient.hasOtherLoggedIn(u.Name) {
+		return ErrLogoutEndsConnection
+	}
+
+	resp, err := u.nutClient.SendCommandContext(ctx, "LOGOUT")
+	if err != nil {
+		return err
+	}
+
+	u.nutClient.removeLoggedIn(u.Name)
+
+	if resp[0] != "OK Goodbye" && resp[0] != "Goodbye..." {
+		return fmt.Errorf("unexpected LOGOUT response: %s", resp[0])
+	}
+
+	return nil
+}
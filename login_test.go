@@ -0,0 +1,59 @@
+package nut
+
+import "testing"
+
+func TestUPSLogoutRefusesWhileAnotherUPSIsLoggedIn(t *testing.T) {
+	c, serverConn := newPipeClient()
+	defer serverConn.Close()
+
+	c.addLoggedIn("upsA")
+	c.addLoggedIn("upsB")
+
+	upsA := &UPS{Name: "upsA", nutClient: c}
+	if err := upsA.Logout(); err != ErrLogoutEndsConnection {
+		t.Fatalf("Logout() with another UPS still logged in = %v, want %v", err, ErrLogoutEndsConnection)
+	}
+}
+
+func TestCompareDottedVersions(t *testing.T) {
+	tests := []struct {
+		name string
+		a    string
+		b    string
+		want int
+	}{
+		{name: "equal", a: "1.3", b: "1.3", want: 0},
+		{name: "older minor", a: "1.2", b: "1.3", want: -1},
+		{name: "newer major", a: "2.0", b: "1.3", want: 1},
+		{name: "shorter than b", a: "1", b: "1.3", want: -1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := compareDottedVersions(tt.a, tt.b); got != tt.want {
+				t.Errorf("compareDottedVersions(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClientPrimaryCommandVerb(t *testing.T) {
+	tests := []struct {
+		name            string
+		protocolVersion string
+		want            string
+	}{
+		{name: "legacy server", protocolVersion: "1.2", want: "MASTER"},
+		{name: "nut 2.8+ server", protocolVersion: "1.3", want: "PRIMARY"},
+		{name: "newer server", protocolVersion: "2.0", want: "PRIMARY"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Client{ProtocolVersion: tt.protocolVersion}
+			if got := c.primaryCommandVerb(); got != tt.want {
+				t.Errorf("primaryCommandVerb() with ProtocolVersion %q = %q, want %q", tt.protocolVersion, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,281 @@
+package nut
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Status flag tokens as found in the "ups.status" variable. See docs/net-protocol.txt.
+const (
+	StatusOnline         = "OL"
+	StatusOnBattery      = "OB"
+	StatusLowBattery     = "LB"
+	StatusHighBattery    = "HB"
+	StatusReplaceBattery = "RB"
+	StatusCharging       = "CHRG"
+	StatusDischarging    = "DISCHRG"
+	StatusBypass         = "BYPASS"
+	StatusCalibration    = "CAL"
+	StatusOff            = "OFF"
+	StatusOverload       = "OVER"
+	StatusTrim           = "TRIM"
+	StatusBoost          = "BOOST"
+	StatusForcedShutdown = "FSD"
+)
+
+// Event describes a single variable that changed value between two polls of a Monitor.
+type Event struct {
+	UPSName     string
+	Variable    string
+	OldValue    interface{}
+	NewValue    interface{}
+	Timestamp   time.Time
+	StatusFlags []string
+
+	// Err is set, with Timestamp the only other field filled in, when the Monitor failed to refresh a UPS.
+	// A transient error does not tear down the event channel; the Monitor simply retries on the next tick.
+	Err error
+}
+
+// IsError reports whether this Event represents a failed refresh rather than a variable change.
+func (e Event) IsError() bool {
+	return e.Err != nil
+}
+
+// variableMeta caches the parts of a Variable that GetVariableDescription/GetVariableType fetch, so a Monitor
+// only has to ask upsd for them once per UPS/variable pair instead of on every tick.
+type variableMeta struct {
+	Type          string
+	Description   string
+	Writeable     bool
+	MaximumLength int
+}
+
+// Monitor periodically polls a Client for every UPS it serves and emits an Event for each variable whose value
+// changes between ticks, along the lines of the netdata go.d upsd collector.
+type Monitor struct {
+	client *Client
+
+	mu        sync.Mutex
+	snapshots map[string]map[string]Variable
+	metadata  map[string]map[string]variableMeta
+}
+
+// NewMonitor returns a Monitor that polls client.
+func NewMonitor(client *Client) *Monitor {
+	return &Monitor{
+		client:    client,
+		snapshots: map[string]map[string]Variable{},
+		metadata:  map[string]map[string]variableMeta{},
+	}
+}
+
+// Start begins polling every interval and returns a channel of Events. The first poll happens immediately rather
+// than waiting for the first tick. The returned channel is closed when ctx is canceled.
+func (m *Monitor) Start(ctx context.Context, interval time.Duration) (<-chan Event, error) {
+	events := make(chan Event)
+
+	go func() {
+		defer close(events)
+
+		m.poll(ctx, events)
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				m.poll(ctx, events)
+			}
+		}
+	}()
+
+	return events, nil
+}
+
+// poll refreshes every UPS known to the Monitor's Client and sends one Event per changed variable, or a single
+// Event with Err set if the refresh itself failed.
+func (m *Monitor) poll(ctx context.Context, events chan<- Event) {
+	names, err := m.listUPSNames(ctx)
+	if err != nil {
+		sendEvent(ctx, events, Event{Err: err, Timestamp: time.Now()})
+		return
+	}
+
+	var refreshErr error
+	for _, name := range names {
+		changed, err := m.refreshUPS(ctx, name)
+		if err != nil {
+			if refreshErr == nil {
+				refreshErr = err
+			}
+			continue
+		}
+		for _, event := range changed {
+			sendEvent(ctx, events, event)
+		}
+	}
+
+	if refreshErr != nil {
+		sendEvent(ctx, events, Event{Err: refreshErr, Timestamp: time.Now()})
+	}
+}
+
+// sendEvent delivers event unless ctx is already canceled, so a slow or absent reader can't wedge the poll loop
+// past the caller giving up.
+func sendEvent(ctx context.Context, events chan<- Event, event Event) {
+	select {
+	case events <- event:
+	case <-ctx.Done():
+	}
+}
+
+// listUPSNames returns the names of every UPS known to upsd, without the overhead of NewUPS building a full UPS
+// (clients, commands, description, variables) for each one.
+func (m *Monitor) listUPSNames(ctx context.Context) ([]string, error) {
+	resp, err := m.client.SendCommandContext(ctx, "LIST UPS")
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+	for _, line := range resp {
+		if !strings.HasPrefix(line, "UPS ") {
+			continue
+		}
+		fields, err := parseLine(line)
+		if err != nil {
+			return nil, err
+		}
+		names = append(names, fields[1])
+	}
+
+	return names, nil
+}
+
+// refreshUPS re-issues LIST VAR for upsName, diffs it against the last snapshot and returns one Event per
+// changed variable. Variable descriptions and types are fetched at most once per UPS/variable pair and cached
+// on the Monitor, rather than on every tick the way UPS.GetVariables does.
+func (m *Monitor) refreshUPS(ctx context.Context, upsName string) ([]Event, error) {
+	resp, err := m.client.SendCommandContext(ctx, "LIST VAR "+upsName)
+	if err != nil {
+		return nil, err
+	}
+
+	// A bare UPS is enough to reuse GetVariableDescription/GetVariableType below; building one through NewUPS
+	// would re-fetch clients, commands and every variable's metadata on every single tick.
+	ups := UPS{Name: upsName, nutClient: m.client}
+
+	var fields [][]string
+	for _, line := range resp[1 : len(resp)-1] {
+		f, err := parseLine(line)
+		if err != nil {
+			return nil, err
+		}
+		fields = append(fields, f)
+	}
+
+	m.mu.Lock()
+	meta, ok := m.metadata[upsName]
+	if !ok {
+		meta = map[string]variableMeta{}
+	}
+	m.mu.Unlock()
+
+	// Fetching a new variable's description/type is a blocking round trip to upsd, so it happens outside the
+	// Monitor's mutex: holding m.mu here would block monitorCollector.Collect's read of m.snapshots for as long
+	// as upsd takes to answer, on every tick that sees a variable it hasn't cached yet.
+	for _, f := range fields {
+		// f is ["VAR", upsName, varName, value]
+		varName := f[2]
+		if _, ok := meta[varName]; ok {
+			continue
+		}
+		description, err := ups.GetVariableDescriptionContext(ctx, varName)
+		if err != nil {
+			return nil, err
+		}
+		varType, writeable, maximumLength, err := ups.GetVariableTypeContext(ctx, varName)
+		if err != nil {
+			return nil, err
+		}
+		meta[varName] = variableMeta{Type: varType, Description: description, Writeable: writeable, MaximumLength: maximumLength}
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.metadata[upsName] = meta
+	previous := m.snapshots[upsName]
+	current := map[string]Variable{}
+	now := time.Now()
+
+	var changed []Event
+
+	for _, f := range fields {
+		varName, rawValue := f[2], f[3]
+		vm := meta[varName]
+
+		newVar := Variable{
+			Name:          varName,
+			Value:         convertVariableValue(rawValue, vm.Type),
+			Type:          vm.Type,
+			Description:   vm.Description,
+			Writeable:     vm.Writeable,
+			MaximumLength: vm.MaximumLength,
+		}
+		current[varName] = newVar
+
+		old, existed := previous[varName]
+		if existed && old.Value == newVar.Value {
+			continue
+		}
+
+		event := Event{
+			UPSName:   upsName,
+			Variable:  varName,
+			NewValue:  newVar.Value,
+			Timestamp: now,
+		}
+		if existed {
+			event.OldValue = old.Value
+		}
+		if varName == "ups.status" {
+			event.StatusFlags = strings.Fields(rawValue)
+		}
+		changed = append(changed, event)
+	}
+
+	m.snapshots[upsName] = current
+
+	return changed, nil
+}
+
+// convertVariableValue mirrors the value conversion UPS.GetVariables performs, so Monitor events carry the same
+// Go types (bool, int64, float64, string) that callers already expect from Variable.Value.
+func convertVariableValue(value, varType string) interface{} {
+	switch value {
+	case "enabled":
+		return true
+	case "disabled":
+		return false
+	}
+
+	if varType == "UNKNOWN" || varType == "NUMBER" {
+		if strings.Count(value, ".") == 1 {
+			if converted, err := strconv.ParseFloat(value, 64); err == nil {
+				return converted
+			}
+		} else if converted, err := strconv.ParseInt(value, 10, 64); err == nil {
+			return converted
+		}
+	}
+
+	return value
+}
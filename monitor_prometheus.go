@@ -0,0 +1,57 @@
+package nut
+
+import "github.com/prometheus/client_golang/prometheus"
+
+// monitorCollector implements prometheus.Collector over a Monitor's latest snapshot.
+type monitorCollector struct {
+	monitor *Monitor
+	desc    *prometheus.Desc
+}
+
+// Collector returns a prometheus.Collector that exports every numeric variable last observed by the Monitor as
+// a gauge, labeled by UPS name and variable name. Non-numeric variables (strings, the enabled/disabled booleans
+// GetVariables produces) are skipped, since they don't have a meaningful gauge value.
+func (m *Monitor) Collector() prometheus.Collector {
+	return &monitorCollector{
+		monitor: m,
+		desc: prometheus.NewDesc(
+			"nut_variable",
+			"Numeric value of a NUT UPS variable, as last observed by the Monitor.",
+			[]string{"ups", "variable"},
+			nil,
+		),
+	}
+}
+
+// Describe implements prometheus.Collector.
+func (c *monitorCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.desc
+}
+
+// Collect implements prometheus.Collector.
+func (c *monitorCollector) Collect(ch chan<- prometheus.Metric) {
+	c.monitor.mu.Lock()
+	defer c.monitor.mu.Unlock()
+
+	for upsName, variables := range c.monitor.snapshots {
+		for varName, variable := range variables {
+			value, ok := numericVariableValue(variable.Value)
+			if !ok {
+				continue
+			}
+			ch <- prometheus.MustNewConstMetric(c.desc, prometheus.GaugeValue, value, upsName, varName)
+		}
+	}
+}
+
+// numericVariableValue reports the float64 representation of a Variable.Value, if it has one.
+func numericVariableValue(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case float64:
+		return v, true
+	case int64:
+		return float64(v), true
+	default:
+		return 0, false
+	}
+}
@@ -0,0 +1,128 @@
+package nut
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net"
+	"strings"
+	"testing"
+)
+
+// readCommand reads one line off r and reports the failure on errs if it isn't want, otherwise writes respLines
+// (each sent as its own newline-terminated write) back to serverConn.
+func readCommand(serverConn net.Conn, r *bufio.Reader, want string, errs chan<- error, respLines ...string) bool {
+	line, err := r.ReadString('\n')
+	if err != nil {
+		errs <- fmt.Errorf("read command: %w", err)
+		return false
+	}
+	if strings.TrimSuffix(line, "\n") != want {
+		errs <- fmt.Errorf("unexpected command %q, want %q", line, want)
+		return false
+	}
+	for _, l := range respLines {
+		if _, err := fmt.Fprintf(serverConn, "%s\n", l); err != nil {
+			errs <- fmt.Errorf("write response: %w", err)
+			return false
+		}
+	}
+	return true
+}
+
+// TestMonitorPollEmitsEventAndThenErrorEvent drives one Monitor.poll tick against a fake upsd standing on the
+// server side of a net.Pipe, then closes the connection and drives a second tick to confirm a failed refresh is
+// reported as an Event with Err set rather than panicking or blocking.
+func TestMonitorPollEmitsEventAndThenErrorEvent(t *testing.T) {
+	c, serverConn := newPipeClient()
+
+	errs := make(chan error, 10)
+	serverDone := make(chan struct{})
+	go func() {
+		defer close(serverDone)
+		r := bufio.NewReader(serverConn)
+
+		if !readCommand(serverConn, r, "LIST UPS", errs,
+			"LIST UPS", `UPS myups "Diesel generator room UPS"`, "END LIST UPS") {
+			return
+		}
+		if !readCommand(serverConn, r, "LIST VAR myups", errs,
+			"LIST VAR myups", `VAR myups ups.status "OL"`, "END LIST VAR myups") {
+			return
+		}
+		if !readCommand(serverConn, r, "GET DESC myups ups.status", errs,
+			`DESC myups ups.status "Status flags"`) {
+			return
+		}
+		if !readCommand(serverConn, r, "GET TYPE myups ups.status", errs, "TYPE myups ups.status STRING") {
+			return
+		}
+
+		// Simulate upsd going away mid-session: the next request the Monitor sends gets no response at all.
+		serverConn.Close()
+	}()
+
+	m := NewMonitor(c)
+	events := make(chan Event, 10)
+
+	m.poll(context.Background(), events)
+	close(errs)
+	for err := range errs {
+		t.Error(err)
+	}
+	<-serverDone
+
+	select {
+	case event := <-events:
+		if event.IsError() {
+			t.Fatalf("first poll: unexpected error event: %v", event.Err)
+		}
+		if event.UPSName != "myups" || event.Variable != "ups.status" || event.NewValue != "OL" {
+			t.Fatalf("first poll: unexpected event %+v", event)
+		}
+		if event.OldValue != nil {
+			t.Fatalf("first poll: OldValue = %#v, want nil (no prior snapshot)", event.OldValue)
+		}
+	default:
+		t.Fatal("first poll: expected one Event, got none")
+	}
+	select {
+	case event := <-events:
+		t.Fatalf("first poll: expected exactly one Event, got an extra one: %+v", event)
+	default:
+	}
+
+	m.poll(context.Background(), events)
+
+	select {
+	case event := <-events:
+		if !event.IsError() {
+			t.Fatalf("second poll: expected an error event after the connection was closed, got %+v", event)
+		}
+	default:
+		t.Fatal("second poll: expected an error Event, got none")
+	}
+}
+
+func TestConvertVariableValue(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		varType string
+		want    interface{}
+	}{
+		{name: "enabled boolean", value: "enabled", varType: "STRING", want: true},
+		{name: "disabled boolean", value: "disabled", varType: "STRING", want: false},
+		{name: "unknown float", value: "13.50", varType: "UNKNOWN", want: 13.5},
+		{name: "number integer", value: "100", varType: "NUMBER", want: int64(100)},
+		{name: "plain string", value: "Eaton", varType: "STRING", want: "Eaton"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := convertVariableValue(tt.value, tt.varType); got != tt.want {
+				t.Errorf("convertVariableValue(%q, %q) = %#v, want %#v", tt.value, tt.varType, got, tt.want)
+			}
+		})
+	}
+}
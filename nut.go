@@ -6,10 +6,13 @@ package nut
 import (
 	"bufio"
 	"context"
+	"crypto/tls"
 	"fmt"
 	"io"
 	"net"
 	"strings"
+	"sync"
+	"time"
 )
 
 // Client contains information about the NUT server as well as the connection.
@@ -17,11 +20,54 @@ type Client struct {
 	Version         string
 	ProtocolVersion string
 
-	conn net.Conn
+	conn       net.Conn
+	reader     *bufio.Reader
+	tlsEnabled bool
+	requireTLS bool
+
+	// mu serializes request/response pairs, since upsd's protocol is strictly one-request-at-a-time per
+	// connection and SendCommandContext reads from and writes to the same conn/reader.
+	mu sync.Mutex
+
+	// loggedInMu guards loggedIn, which tracks the UPSes this connection has sent LOGIN for, so Disconnect can
+	// release them.
+	loggedInMu sync.Mutex
+	loggedIn   map[string]bool
+}
+
+// ClientConfig holds the optional configuration applied by ClientOptions passed to NewClient.
+type ClientConfig struct {
+	// TLSConfig, if non-nil, causes NewClient to negotiate STARTTLS using it immediately after connecting.
+	TLSConfig *tls.Config
+	// RequireTLS refuses to send USERNAME/PASSWORD until the connection has been upgraded via STARTTLS.
+	RequireTLS bool
+}
+
+// ClientOption configures a ClientConfig. See WithTLS and WithRequireTLS.
+type ClientOption func(*ClientConfig)
+
+// WithTLS causes NewClient to issue STARTTLS right after connecting, then upgrade the connection using tlsConfig.
+func WithTLS(tlsConfig *tls.Config) ClientOption {
+	return func(c *ClientConfig) {
+		c.TLSConfig = tlsConfig
+	}
+}
+
+// WithRequireTLS refuses to send USERNAME/PASSWORD over a connection that hasn't been upgraded via STARTTLS,
+// returning ErrTLSRequired instead. Combine with WithTLS so the upgrade happens before Authenticate is called.
+func WithRequireTLS() ClientOption {
+	return func(c *ClientConfig) {
+		c.RequireTLS = true
+	}
 }
 
 // NewClient accepts a hostname/IP string and an optional port, then creates a connection to NUT, returning a Client.
-func NewClient(ctx context.Context, hostname string, port int) (*Client, error) {
+func NewClient(ctx context.Context, hostname string, port int, opts ...ClientOption) (*Client, error) {
+	cfg := &ClientConfig{}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
 	d := &net.Dialer{}
 
 	conn, err := d.DialContext(ctx, "tcp", fmt.Sprintf("%s:%d", hostname, port))
@@ -30,15 +76,24 @@ func NewClient(ctx context.Context, hostname string, port int) (*Client, error)
 	}
 
 	client := Client{
-		conn: conn,
+		conn:       conn,
+		reader:     bufio.NewReader(conn),
+		requireTLS: cfg.RequireTLS,
+		loggedIn:   map[string]bool{},
 	}
 
-	client.Version, err = client.GetVersion()
+	if cfg.TLSConfig != nil {
+		if err := client.startTLS(ctx, cfg.TLSConfig); err != nil {
+			return nil, fmt.Errorf("%w: start tls fail", err)
+		}
+	}
+
+	client.Version, err = client.GetVersionContext(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("%w: get version fail", err)
 	}
 
-	client.ProtocolVersion, err = client.GetNetworkProtocolVersion()
+	client.ProtocolVersion, err = client.GetNetworkProtocolVersionContext(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("%w: get network protocol version fail", err)
 	}
@@ -46,12 +101,48 @@ func NewClient(ctx context.Context, hostname string, port int) (*Client, error)
 	return &client, nil
 }
 
+// startTLS sends STARTTLS, waits for upsd's acknowledgement, then upgrades the connection to TLS using tlsConfig,
+// honoring ctx for both the STARTTLS round trip and the handshake. It must be called before any other traffic is
+// sent, since the bufio.Reader backing the plaintext connection is replaced and any bytes already buffered past
+// the STARTTLS reply would otherwise be lost.
+func (c *Client) startTLS(ctx context.Context, tlsConfig *tls.Config) error {
+	resp, err := c.SendCommandContext(ctx, "STARTTLS")
+	if err != nil {
+		return err
+	}
+	if resp[0] != "OK STARTTLS" {
+		return fmt.Errorf("unexpected STARTTLS response: %s", resp[0])
+	}
+
+	tlsConn := tls.Client(c.conn, tlsConfig)
+	if err := tlsConn.HandshakeContext(ctx); err != nil {
+		return fmt.Errorf("%w: tls handshake fail", err)
+	}
+
+	c.conn = tlsConn
+	c.reader = bufio.NewReader(tlsConn)
+	c.tlsEnabled = true
+
+	return nil
+}
+
 // Disconnect gracefully disconnects from NUT by sending the LOGOUT command.
 func (c *Client) Disconnect() (bool, error) {
-	logoutResp, err := c.SendCommand("LOGOUT")
+	return c.DisconnectContext(context.Background())
+}
+
+// DisconnectContext gracefully disconnects from NUT by sending the LOGOUT command, honoring ctx. Any UPSes
+// logged in via UPS.Login on this connection are released, since a single LOGOUT ends them all.
+func (c *Client) DisconnectContext(ctx context.Context) (bool, error) {
+	logoutResp, err := c.SendCommandContext(ctx, "LOGOUT")
 	if err != nil {
 		return false, fmt.Errorf("%w: send command fail", err)
 	}
+
+	c.loggedInMu.Lock()
+	c.loggedIn = map[string]bool{}
+	c.loggedInMu.Unlock()
+
 	if logoutResp[0] == "OK Goodbye" || logoutResp[0] == "Goodbye..." {
 		return true, nil
 	}
@@ -61,12 +152,10 @@ func (c *Client) Disconnect() (bool, error) {
 
 // ReadResponse is a convenience function for reading newline delimited responses.
 func (c *Client) ReadResponse(endLine string, multiLineResponse bool) ([]string, error) {
-	connbuff := bufio.NewReader(c.conn)
-
 	var response []string
 
 	for {
-		line, err := connbuff.ReadString('\n')
+		line, err := c.reader.ReadString('\n')
 		if err != nil && err != io.EOF {
 			return nil, fmt.Errorf("%w: error reading response", err)
 		}
@@ -85,7 +174,23 @@ func (c *Client) ReadResponse(endLine string, multiLineResponse bool) ([]string,
 }
 
 // SendCommand sends the string cmd to the device, and returns the response.
+//
+// It is a thin wrapper around SendCommandContext using context.Background(), kept for source compatibility.
 func (c *Client) SendCommand(cmd string) ([]string, error) {
+	return c.SendCommandContext(context.Background(), cmd)
+}
+
+// SendCommandContext sends the string cmd to the device and returns the response, honoring ctx's deadline and
+// cancellation. If ctx is canceled or its deadline is exceeded while a response is outstanding, the in-flight
+// read is unblocked by forcing the underlying connection's deadline, and a wrapped ctx.Err() is returned.
+func (c *Client) SendCommandContext(ctx context.Context, cmd string) ([]string, error) {
+	if (strings.HasPrefix(cmd, "USERNAME ") || strings.HasPrefix(cmd, "PASSWORD ")) && c.requireTLS && !c.tlsEnabled {
+		return nil, ErrTLSRequired
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
 	cmd = fmt.Sprintf("%v\n", cmd)
 	endLine := fmt.Sprintf("END %s", cmd)
 
@@ -98,12 +203,41 @@ func (c *Client) SendCommand(cmd string) ([]string, error) {
 		endLine = "OK\n"
 	}
 
+	// The watcher goroutine below may force the connection's deadline into the past to unblock a canceled
+	// read. That must be undone unconditionally when this call returns, even for a context.Background() or
+	// otherwise deadline-less ctx, or every later call on this shared Client would fail instantly with an
+	// i/o timeout left over from this one.
+	if deadline, ok := ctx.Deadline(); ok {
+		if err := c.conn.SetDeadline(deadline); err != nil {
+			return nil, fmt.Errorf("%w: set deadline fail", err)
+		}
+	}
+
+	done := make(chan struct{})
+	watcherDone := make(chan struct{})
+	go func() {
+		defer close(watcherDone)
+		select {
+		case <-ctx.Done():
+			c.conn.SetDeadline(time.Now())
+		case <-done:
+		}
+	}()
+	defer func() {
+		close(done)
+		<-watcherDone
+		c.conn.SetDeadline(time.Time{})
+	}()
+
 	if _, err := fmt.Fprint(c.conn, cmd); err != nil {
 		return nil, fmt.Errorf("%w: fprint fail", err)
 	}
 
 	resp, err := c.ReadResponse(endLine, strings.HasPrefix(cmd, "LIST "))
 	if err != nil {
+		if ctx.Err() != nil {
+			return nil, fmt.Errorf("%w: command canceled", ctx.Err())
+		}
 		return nil, fmt.Errorf("%w: read response fail", err)
 	}
 	if strings.HasPrefix(resp[0], "ERR ") {
@@ -115,12 +249,18 @@ func (c *Client) SendCommand(cmd string) ([]string, error) {
 
 // Authenticate accepts a username and passwords and uses them to authenticate the existing NUT session.
 func (c *Client) Authenticate(username, password string) (bool, error) {
-	usernameResp, err := c.SendCommand(fmt.Sprintf("USERNAME %s", username))
+	return c.AuthenticateContext(context.Background(), username, password)
+}
+
+// AuthenticateContext accepts a username and password and uses them to authenticate the existing NUT session,
+// honoring ctx.
+func (c *Client) AuthenticateContext(ctx context.Context, username, password string) (bool, error) {
+	usernameResp, err := c.SendCommandContext(ctx, fmt.Sprintf("USERNAME %s", username))
 	if err != nil {
 		return false, fmt.Errorf("%w: send command username fail", err)
 	}
 
-	passwordResp, err := c.SendCommand(fmt.Sprintf("PASSWORD %s", password))
+	passwordResp, err := c.SendCommandContext(ctx, fmt.Sprintf("PASSWORD %s", password))
 	if err != nil {
 		return false, fmt.Errorf("%w: send command password fail", err)
 	}
@@ -133,7 +273,12 @@ func (c *Client) Authenticate(username, password string) (bool, error) {
 
 // GetUPSList returns a list of all UPSes provided by this NUT instance.
 func (c *Client) GetUPSList() ([]*UPS, error) {
-	resp, err := c.SendCommand("LIST UPS")
+	return c.GetUPSListContext(context.Background())
+}
+
+// GetUPSListContext returns a list of all UPSes provided by this NUT instance, honoring ctx.
+func (c *Client) GetUPSListContext(ctx context.Context) ([]*UPS, error) {
+	resp, err := c.SendCommandContext(ctx, "LIST UPS")
 	if err != nil {
 		return nil, fmt.Errorf("%w: send command ups list fail", err)
 	}
@@ -142,14 +287,17 @@ func (c *Client) GetUPSList() ([]*UPS, error) {
 
 	for _, line := range resp {
 		if strings.HasPrefix(line, "UPS ") {
-			splitLine := strings.Split(strings.TrimPrefix(line, "UPS "), `"`)
+			fields, err := parseLine(line)
+			if err != nil {
+				return nil, fmt.Errorf("%w: parse ups list line fail", err)
+			}
 
-			ups, err := NewUPS(strings.TrimSuffix(splitLine[0], " "), c)
+			ups, err := NewUPSContext(ctx, fields[1], c)
 			if err != nil {
 				return nil, fmt.Errorf("%w: prepare ups list fail", err)
 			}
 
-			upsList = append(upsList, ups)
+			upsList = append(upsList, &ups)
 		}
 	}
 
@@ -158,7 +306,12 @@ func (c *Client) GetUPSList() ([]*UPS, error) {
 
 // Help returns a list of the commands supported by NUT.
 func (c *Client) Help() (string, error) {
-	helpResp, err := c.SendCommand("HELP")
+	return c.HelpContext(context.Background())
+}
+
+// HelpContext returns a list of the commands supported by NUT, honoring ctx.
+func (c *Client) HelpContext(ctx context.Context) (string, error) {
+	helpResp, err := c.SendCommandContext(ctx, "HELP")
 	if err != nil || len(helpResp) < 1 {
 		return "", fmt.Errorf("%w: send command help fail", err)
 	}
@@ -168,7 +321,12 @@ func (c *Client) Help() (string, error) {
 
 // GetVersion returns the the version of the server currently in use.
 func (c *Client) GetVersion() (string, error) {
-	versionResponse, err := c.SendCommand("VER")
+	return c.GetVersionContext(context.Background())
+}
+
+// GetVersionContext returns the version of the server currently in use, honoring ctx.
+func (c *Client) GetVersionContext(ctx context.Context) (string, error) {
+	versionResponse, err := c.SendCommandContext(ctx, "VER")
 	if err != nil || len(versionResponse) < 1 {
 		return "", fmt.Errorf("%w: send command var fail", err)
 	}
@@ -178,7 +336,12 @@ func (c *Client) GetVersion() (string, error) {
 
 // GetNetworkProtocolVersion returns the version of the network protocol currently in use.
 func (c *Client) GetNetworkProtocolVersion() (string, error) {
-	versionResponse, err := c.SendCommand("NETVER")
+	return c.GetNetworkProtocolVersionContext(context.Background())
+}
+
+// GetNetworkProtocolVersionContext returns the version of the network protocol currently in use, honoring ctx.
+func (c *Client) GetNetworkProtocolVersionContext(ctx context.Context) (string, error) {
+	versionResponse, err := c.SendCommandContext(ctx, "NETVER")
 	if err != nil || len(versionResponse) < 1 {
 		return "", fmt.Errorf("%w: send command netver fail", err)
 	}
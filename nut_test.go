@@ -0,0 +1,219 @@
+package nut
+
+import (
+	"bufio"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"fmt"
+	"math/big"
+	"net"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// generateSelfSignedCert returns a minimal self-signed TLS certificate for use by a test upsd stand-in; there's
+// no CA involved, so tests that use it must also set tls.Config.InsecureSkipVerify.
+func generateSelfSignedCert(t *testing.T) tls.Certificate {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("generate key: %v", err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "upsd-test"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("create certificate: %v", err)
+	}
+
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key}
+}
+
+// TestClientStartTLSUpgradesConnection exercises the STARTTLS handshake: a fake upsd acknowledges STARTTLS in
+// plaintext, then both sides upgrade to TLS, and a command sent after the handshake must still round-trip
+// correctly over the encrypted connection.
+func TestClientStartTLSUpgradesConnection(t *testing.T) {
+	c, serverConn := newPipeClient()
+	defer serverConn.Close()
+
+	cert := generateSelfSignedCert(t)
+
+	serverErr := make(chan error, 1)
+	go func() {
+		r := bufio.NewReader(serverConn)
+		line, err := r.ReadString('\n')
+		if err != nil {
+			serverErr <- err
+			return
+		}
+		if strings.TrimSuffix(line, "\n") != "STARTTLS" {
+			serverErr <- fmt.Errorf("unexpected command %q", line)
+			return
+		}
+		if _, err := fmt.Fprint(serverConn, "OK STARTTLS\n"); err != nil {
+			serverErr <- err
+			return
+		}
+
+		tlsServerConn := tls.Server(serverConn, &tls.Config{Certificates: []tls.Certificate{cert}})
+		if err := tlsServerConn.HandshakeContext(context.Background()); err != nil {
+			serverErr <- fmt.Errorf("server handshake: %w", err)
+			return
+		}
+
+		tr := bufio.NewReader(tlsServerConn)
+		line, err = tr.ReadString('\n')
+		if err != nil {
+			serverErr <- err
+			return
+		}
+		if strings.TrimSuffix(line, "\n") != "VER" {
+			serverErr <- fmt.Errorf("unexpected command %q", line)
+			return
+		}
+		_, err = fmt.Fprint(tlsServerConn, "VER 1.0\n")
+		serverErr <- err
+	}()
+
+	if err := c.startTLS(context.Background(), &tls.Config{InsecureSkipVerify: true}); err != nil {
+		t.Fatalf("startTLS() = %v", err)
+	}
+	if !c.tlsEnabled {
+		t.Error("tlsEnabled = false after a successful STARTTLS handshake")
+	}
+
+	resp, err := c.SendCommand("VER")
+	if err != nil {
+		t.Fatalf("SendCommand() after STARTTLS = %v", err)
+	}
+	if resp[0] != "VER 1.0" {
+		t.Errorf("resp[0] = %q, want %q", resp[0], "VER 1.0")
+	}
+
+	if err := <-serverErr; err != nil {
+		t.Errorf("server side: %v", err)
+	}
+}
+
+// newPipeClient returns a Client wired up to an in-memory net.Pipe, along with the server side of the pipe, so
+// tests can stand in for upsd without a real network connection.
+func newPipeClient() (*Client, net.Conn) {
+	clientConn, serverConn := net.Pipe()
+	return &Client{
+		conn:     clientConn,
+		reader:   bufio.NewReader(clientConn),
+		loggedIn: map[string]bool{},
+	}, serverConn
+}
+
+// TestSendCommandContextConcurrentCallsDoNotInterleave exercises the mutex added to make Client safe for
+// concurrent use: many goroutines hammering SendCommand on the same Client must each get back the response to
+// their own request, never a response meant for someone else.
+func TestSendCommandContextConcurrentCallsDoNotInterleave(t *testing.T) {
+	c, serverConn := newPipeClient()
+	defer serverConn.Close()
+
+	const n = 20
+
+	var received int32
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		r := bufio.NewReader(serverConn)
+		for i := 0; i < n; i++ {
+			line, err := r.ReadString('\n')
+			if err != nil {
+				return
+			}
+			atomic.AddInt32(&received, 1)
+			// Echo the command back, so each caller can tell its own response from anyone else's.
+			if _, err := fmt.Fprint(serverConn, line); err != nil {
+				return
+			}
+		}
+	}()
+
+	var wg sync.WaitGroup
+	errs := make(chan error, n)
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			cmd := fmt.Sprintf("HELP %d", i)
+			resp, err := c.SendCommand(cmd)
+			if err != nil {
+				errs <- err
+				return
+			}
+			if resp[0] != cmd {
+				errs <- fmt.Errorf("command %q got response %q", cmd, resp[0])
+			}
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Error(err)
+	}
+
+	<-done
+	if got := atomic.LoadInt32(&received); got != n {
+		t.Errorf("server received %d commands, want %d", got, n)
+	}
+}
+
+// TestSendCommandContextCancelWithoutDeadlineRecovers reproduces the bug where canceling a deadline-less
+// context (e.g. context.WithCancel) left the connection's deadline stuck in the past, poisoning every later
+// call on the shared Client, including ones using context.Background().
+func TestSendCommandContextCancelWithoutDeadlineRecovers(t *testing.T) {
+	c, serverConn := newPipeClient()
+	defer serverConn.Close()
+
+	go func() {
+		r := bufio.NewReader(serverConn)
+		// First command: read it off the wire but never respond, simulating a stuck/slow upsd.
+		if _, err := r.ReadString('\n'); err != nil {
+			return
+		}
+		// Second command: respond normally.
+		if _, err := r.ReadString('\n'); err != nil {
+			return
+		}
+		fmt.Fprint(serverConn, "VER 1.0\n")
+	}()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		cancel()
+	}()
+
+	if _, err := c.SendCommandContext(ctx, "VER"); err == nil {
+		t.Fatal("expected the canceled call to return an error")
+	}
+
+	resp, err := c.SendCommandContext(context.Background(), "VER")
+	if err != nil {
+		t.Fatalf("call after a deadline-less cancellation should succeed, got: %v", err)
+	}
+	if resp[0] != "VER 1.0" {
+		t.Errorf("resp[0] = %q, want %q", resp[0], "VER 1.0")
+	}
+}
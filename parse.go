@@ -0,0 +1,54 @@
+package nut
+
+import (
+	"encoding/csv"
+	"fmt"
+	"strings"
+)
+
+// parseLine splits a single line of a NUT reply into its token fields, honoring the quoting rules described in
+// docs/net-protocol.txt: fields are separated by spaces, and any field containing a space must be wrapped in
+// double quotes, with an embedded quote escaped as \" and an embedded backslash escaped as \\. It is
+// implemented on top of encoding/csv, configured to treat a space as the field separator, so values like
+// `"a value with spaces"` or `"an \"escaped\" quote"` round-trip correctly instead of being torn apart by a
+// naive strings.Split on `"`.
+func parseLine(s string) ([]string, error) {
+	r := csv.NewReader(strings.NewReader(unescapeNUTQuoting(s)))
+	r.Comma = ' '
+	r.LazyQuotes = true
+
+	fields, err := r.Read()
+	if err != nil {
+		return nil, fmt.Errorf("%w: parse line fail", err)
+	}
+
+	return fields, nil
+}
+
+// unescapeNUTQuoting translates NUT's backslash-escaped quoting into encoding/csv's own convention, so csv.Reader
+// can be used to tokenize the line: \" (an escaped literal quote) becomes "" (CSV's doubled-quote escape), and
+// \\ (an escaped literal backslash) collapses to a single \. It is a single left-to-right pass so a trailing
+// escaped backslash right before a field's closing quote (\\") is resolved as "backslash, then unescaped closing
+// quote" rather than being misread as an escaped quote.
+func unescapeNUTQuoting(s string) string {
+	var b strings.Builder
+	b.Grow(len(s))
+
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' && i+1 < len(s) {
+			switch s[i+1] {
+			case '"':
+				b.WriteString(`""`)
+				i++
+				continue
+			case '\\':
+				b.WriteByte('\\')
+				i++
+				continue
+			}
+		}
+		b.WriteByte(s[i])
+	}
+
+	return b.String()
+}
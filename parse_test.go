@@ -0,0 +1,63 @@
+package nut
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseLine(t *testing.T) {
+	tests := []struct {
+		name    string
+		line    string
+		want    []string
+		wantErr bool
+	}{
+		{
+			name: "simple variable value",
+			line: `VAR ups battery.charge "100"`,
+			want: []string{"VAR", "ups", "battery.charge", "100"},
+		},
+		{
+			name: "value with embedded spaces",
+			line: `VAR ups ups.status "OL CHRG"`,
+			want: []string{"VAR", "ups", "ups.status", "OL CHRG"},
+		},
+		{
+			name: "description with an escaped quote",
+			line: `DESC ups battery.charge "Battery \"charge\" level"`,
+			want: []string{"DESC", "ups", "battery.charge", `Battery "charge" level`},
+		},
+		{
+			name: "empty string value",
+			line: `VAR ups ups.alarm ""`,
+			want: []string{"VAR", "ups", "ups.alarm", ""},
+		},
+		{
+			name: "multi-word ups description",
+			line: `UPS myups "Diesel generator room UPS"`,
+			want: []string{"UPS", "myups", "Diesel generator room UPS"},
+		},
+		{
+			name: "embedded literal backslash",
+			line: `VAR ups driver.path "C:\\Program Files\\nut"`,
+			want: []string{"VAR", "ups", "driver.path", `C:\Program Files\nut`},
+		},
+		{
+			name: "escaped backslash immediately before closing quote",
+			line: `VAR ups driver.path "trailing\\"`,
+			want: []string{"VAR", "ups", "driver.path", `trailing\`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseLine(tt.line)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseLine(%q) error = %v, wantErr %v", tt.line, err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseLine(%q) = %#v, want %#v", tt.line, got, tt.want)
+			}
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package nut
 
 import (
+	"context"
 	"fmt"
 	"strconv"
 	"strings"
@@ -37,27 +38,32 @@ type Command struct {
 
 // NewUPS takes a UPS name and NUT client and returns an instantiated UPS struct.
 func NewUPS(name string, client *Client) (UPS, error) {
+	return NewUPSContext(context.Background(), name, client)
+}
+
+// NewUPSContext takes a UPS name and NUT client and returns an instantiated UPS struct, honoring ctx.
+func NewUPSContext(ctx context.Context, name string, client *Client) (UPS, error) {
 	newUPS := UPS{
 		Name:      name,
 		nutClient: client,
 	}
-	_, err := newUPS.GetClients()
+	_, err := newUPS.GetClientsContext(ctx)
 	if err != nil {
 		return newUPS, err
 	}
-	_, err = newUPS.GetCommands()
+	_, err = newUPS.GetCommandsContext(ctx)
 	if err != nil {
 		return newUPS, err
 	}
-	_, err = newUPS.GetDescription()
+	_, err = newUPS.GetDescriptionContext(ctx)
 	if err != nil {
 		return newUPS, err
 	}
-	_, err = newUPS.GetNumberOfLogins()
+	_, err = newUPS.GetNumberOfLoginsContext(ctx)
 	if err != nil {
 		return newUPS, err
 	}
-	_, err = newUPS.GetVariables()
+	_, err = newUPS.GetVariablesContext(ctx)
 	if err != nil {
 		return newUPS, err
 	}
@@ -66,7 +72,12 @@ func NewUPS(name string, client *Client) (UPS, error) {
 
 // GetNumberOfLogins returns the number of clients which have done LOGIN for this UPS.
 func (u *UPS) GetNumberOfLogins() (int, error) {
-	resp, err := u.nutClient.SendCommand(fmt.Sprintf("GET NUMLOGINS %s", u.Name))
+	return u.GetNumberOfLoginsContext(context.Background())
+}
+
+// GetNumberOfLoginsContext returns the number of clients which have done LOGIN for this UPS, honoring ctx.
+func (u *UPS) GetNumberOfLoginsContext(ctx context.Context) (int, error) {
+	resp, err := u.nutClient.SendCommandContext(ctx, fmt.Sprintf("GET NUMLOGINS %s", u.Name))
 	if err != nil {
 		return 0, err
 	}
@@ -80,8 +91,13 @@ func (u *UPS) GetNumberOfLogins() (int, error) {
 
 // GetClients returns a list of NUT clients.
 func (u *UPS) GetClients() ([]string, error) {
+	return u.GetClientsContext(context.Background())
+}
+
+// GetClientsContext returns a list of NUT clients, honoring ctx.
+func (u *UPS) GetClientsContext(ctx context.Context) ([]string, error) {
 	clientsList := []string{}
-	resp, err := u.nutClient.SendCommand(fmt.Sprintf("LIST CLIENT %s", u.Name))
+	resp, err := u.nutClient.SendCommandContext(ctx, fmt.Sprintf("LIST CLIENT %s", u.Name))
 	if err != nil {
 		return clientsList, err
 	}
@@ -94,8 +110,16 @@ func (u *UPS) GetClients() ([]string, error) {
 }
 
 // CheckIfMaster returns true if the session is authenticated with the master permission set.
+//
+// This sends the legacy MASTER verb, or its NUT 2.8+ replacement PRIMARY, depending on Client.ProtocolVersion.
 func (u *UPS) CheckIfMaster() (bool, error) {
-	resp, err := u.nutClient.SendCommand(fmt.Sprintf("MASTER %s", u.Name))
+	return u.CheckIfMasterContext(context.Background())
+}
+
+// CheckIfMasterContext returns true if the session is authenticated with the master permission set, honoring ctx.
+func (u *UPS) CheckIfMasterContext(ctx context.Context) (bool, error) {
+	verb := u.nutClient.primaryCommandVerb()
+	resp, err := u.nutClient.SendCommandContext(ctx, fmt.Sprintf("%s %s", verb, u.Name))
 	if err != nil {
 		return false, err
 	}
@@ -108,7 +132,12 @@ func (u *UPS) CheckIfMaster() (bool, error) {
 
 // GetDescription the value of "desc=" from ups.conf for this UPS. If it is not set, upsd will return "Unavailable".
 func (u *UPS) GetDescription() (string, error) {
-	resp, err := u.nutClient.SendCommand(fmt.Sprintf("GET UPSDESC %s", u.Name))
+	return u.GetDescriptionContext(context.Background())
+}
+
+// GetDescriptionContext returns the value of "desc=" from ups.conf for this UPS, honoring ctx.
+func (u *UPS) GetDescriptionContext(ctx context.Context) (string, error) {
+	resp, err := u.nutClient.SendCommandContext(ctx, fmt.Sprintf("GET UPSDESC %s", u.Name))
 	if err != nil {
 		return "", err
 	}
@@ -119,30 +148,39 @@ func (u *UPS) GetDescription() (string, error) {
 
 // GetVariables returns a slice of Variable structs for the UPS.
 func (u *UPS) GetVariables() ([]Variable, error) {
+	return u.GetVariablesContext(context.Background())
+}
+
+// GetVariablesContext returns a slice of Variable structs for the UPS, honoring ctx.
+func (u *UPS) GetVariablesContext(ctx context.Context) ([]Variable, error) {
 	vars := []Variable{}
-	resp, err := u.nutClient.SendCommand(fmt.Sprintf("LIST VAR %s", u.Name))
+	resp, err := u.nutClient.SendCommandContext(ctx, fmt.Sprintf("LIST VAR %s", u.Name))
 	if err != nil {
 		return vars, err
 	}
-	offset := fmt.Sprintf("VAR %s ", u.Name)
 	for _, line := range resp[1 : len(resp)-1] {
 		newVar := Variable{}
-		cleanedLine := strings.TrimPrefix(line, offset)
-		splitLine := strings.Split(cleanedLine, `"`)
-		newVar.Name = strings.TrimSuffix(splitLine[0], " ")
-		newVar.Value = splitLine[1]
-		if splitLine[1] == "enabled" {
+
+		fields, err := parseLine(line)
+		if err != nil {
+			return vars, fmt.Errorf("%w: parse variable line fail", err)
+		}
+		// fields is ["VAR", upsName, varName, value]
+		newVar.Name = fields[2]
+		value := fields[3]
+		newVar.Value = value
+		if value == "enabled" {
 			newVar.Value = true
 		}
-		if splitLine[1] == "disabled" {
+		if value == "disabled" {
 			newVar.Value = false
 		}
-		description, err := u.GetVariableDescription(newVar.Name)
+		description, err := u.GetVariableDescriptionContext(ctx, newVar.Name)
 		if err != nil {
 			return vars, err
 		}
 		newVar.Description = description
-		varType, writeable, maximumLength, err := u.GetVariableType(newVar.Name)
+		varType, writeable, maximumLength, err := u.GetVariableTypeContext(ctx, newVar.Name)
 		if err != nil {
 			return vars, err
 		}
@@ -150,15 +188,15 @@ func (u *UPS) GetVariables() ([]Variable, error) {
 		newVar.Writeable = writeable
 		newVar.MaximumLength = maximumLength
 		if varType == "UNKNOWN" || varType == "NUMBER" {
-			if strings.Count(splitLine[1], ".") == 1 {
-				converted, err := strconv.ParseFloat(splitLine[1], 64)
+			if strings.Count(value, ".") == 1 {
+				converted, err := strconv.ParseFloat(value, 64)
 				if err == nil {
 					newVar.Value = converted
 					newVar.Type = "FLOAT_64"
 					newVar.OriginalType = varType
 				}
 			} else {
-				converted, err := strconv.ParseInt(splitLine[1], 10, 64)
+				converted, err := strconv.ParseInt(value, 10, 64)
 				if err == nil {
 					newVar.Value = converted
 					newVar.Type = "INTEGER"
@@ -175,18 +213,33 @@ func (u *UPS) GetVariables() ([]Variable, error) {
 // GetVariableDescription returns a string that gives a brief explanation for the given variableName.
 // upsd may return "Unavailable" if the file which provides this description is not installed.
 func (u *UPS) GetVariableDescription(variableName string) (string, error) {
-	resp, err := u.nutClient.SendCommand(fmt.Sprintf("GET DESC %s %s", u.Name, variableName))
+	return u.GetVariableDescriptionContext(context.Background(), variableName)
+}
+
+// GetVariableDescriptionContext returns a string that gives a brief explanation for the given variableName,
+// honoring ctx.
+func (u *UPS) GetVariableDescriptionContext(ctx context.Context, variableName string) (string, error) {
+	resp, err := u.nutClient.SendCommandContext(ctx, fmt.Sprintf("GET DESC %s %s", u.Name, variableName))
 	if err != nil {
 		return "", err
 	}
-	trimmedLine := strings.TrimPrefix(resp[0], fmt.Sprintf("DESC %s %s ", u.Name, variableName))
-	description := strings.Replace(trimmedLine, `"`, "", -1)
-	return description, nil
+	fields, err := parseLine(resp[0])
+	if err != nil {
+		return "", fmt.Errorf("%w: parse variable description line fail", err)
+	}
+	// fields is ["DESC", upsName, varName, description]
+	return fields[3], nil
 }
 
 // GetVariableType returns the variable type, writeability and maximum length for the given variableName.
 func (u *UPS) GetVariableType(variableName string) (string, bool, int, error) {
-	resp, err := u.nutClient.SendCommand(fmt.Sprintf("GET TYPE %s %s", u.Name, variableName))
+	return u.GetVariableTypeContext(context.Background(), variableName)
+}
+
+// GetVariableTypeContext returns the variable type, writeability and maximum length for the given variableName,
+// honoring ctx.
+func (u *UPS) GetVariableTypeContext(ctx context.Context, variableName string) (string, bool, int, error) {
+	resp, err := u.nutClient.SendCommandContext(ctx, fmt.Sprintf("GET TYPE %s %s", u.Name, variableName))
 	if err != nil {
 		return "UNKNOWN", false, -1, err
 	}
@@ -213,8 +266,13 @@ func (u *UPS) GetVariableType(variableName string) (string, bool, int, error) {
 
 // GetCommands returns a slice of Command structs for the UPS.
 func (u *UPS) GetCommands() ([]Command, error) {
+	return u.GetCommandsContext(context.Background())
+}
+
+// GetCommandsContext returns a slice of Command structs for the UPS, honoring ctx.
+func (u *UPS) GetCommandsContext(ctx context.Context) ([]Command, error) {
 	commandsList := []Command{}
-	resp, err := u.nutClient.SendCommand(fmt.Sprintf("LIST CMD %s", u.Name))
+	resp, err := u.nutClient.SendCommandContext(ctx, fmt.Sprintf("LIST CMD %s", u.Name))
 	if err != nil {
 		return commandsList, err
 	}
@@ -224,7 +282,7 @@ func (u *UPS) GetCommands() ([]Command, error) {
 		cmd := Command{
 			Name: cmdName,
 		}
-		description, err := u.GetCommandDescription(cmdName)
+		description, err := u.GetCommandDescriptionContext(ctx, cmdName)
 		if err != nil {
 			return commandsList, err
 		}
@@ -237,18 +295,32 @@ func (u *UPS) GetCommands() ([]Command, error) {
 
 // GetCommandDescription returns a string that gives a brief explanation for the given commandName.
 func (u *UPS) GetCommandDescription(commandName string) (string, error) {
-	resp, err := u.nutClient.SendCommand(fmt.Sprintf("GET CMDDESC %s %s", u.Name, commandName))
+	return u.GetCommandDescriptionContext(context.Background(), commandName)
+}
+
+// GetCommandDescriptionContext returns a string that gives a brief explanation for the given commandName,
+// honoring ctx.
+func (u *UPS) GetCommandDescriptionContext(ctx context.Context, commandName string) (string, error) {
+	resp, err := u.nutClient.SendCommandContext(ctx, fmt.Sprintf("GET CMDDESC %s %s", u.Name, commandName))
 	if err != nil {
 		return "", err
 	}
-	trimmedLine := strings.TrimPrefix(resp[0], fmt.Sprintf("CMDDESC %s %s ", u.Name, commandName))
-	description := strings.Replace(trimmedLine, `"`, "", -1)
-	return description, err
+	fields, err := parseLine(resp[0])
+	if err != nil {
+		return "", fmt.Errorf("%w: parse command description line fail", err)
+	}
+	// fields is ["CMDDESC", upsName, commandName, description]
+	return fields[3], nil
 }
 
 // SetVariable sets the given variableName to the given value on the UPS.
 func (u *UPS) SetVariable(variableName, value string) (bool, error) {
-	resp, err := u.nutClient.SendCommand(fmt.Sprintf(`SET VAR %s %s "%s"`, u.Name, variableName, value))
+	return u.SetVariableContext(context.Background(), variableName, value)
+}
+
+// SetVariableContext sets the given variableName to the given value on the UPS, honoring ctx.
+func (u *UPS) SetVariableContext(ctx context.Context, variableName, value string) (bool, error) {
+	resp, err := u.nutClient.SendCommandContext(ctx, fmt.Sprintf(`SET VAR %s %s "%s"`, u.Name, variableName, value))
 	if err != nil {
 		return false, err
 	}
@@ -260,7 +332,12 @@ func (u *UPS) SetVariable(variableName, value string) (bool, error) {
 
 // SendCommand sends a command to the UPS.
 func (u *UPS) SendCommand(commandName string) (bool, error) {
-	resp, err := u.nutClient.SendCommand(fmt.Sprintf("INSTCMD %s %s", u.Name, commandName))
+	return u.SendCommandContext(context.Background(), commandName)
+}
+
+// SendCommandContext sends a command to the UPS, honoring ctx.
+func (u *UPS) SendCommandContext(ctx context.Context, commandName string) (bool, error) {
+	resp, err := u.nutClient.SendCommandContext(ctx, fmt.Sprintf("INSTCMD %s %s", u.Name, commandName))
 	if err != nil {
 		return false, err
 	}
@@ -280,7 +357,12 @@ func (u *UPS) SendCommand(commandName string) (bool, error) {
 //
 // It should be noted that FSD is currently a latch - once set, there is no way to clear it short of restarting upsd or dropping then re-adding it in the ups.conf. This may cause issues when upsd is running on a system that is not shut down due to the UPS event.
 func (u *UPS) ForceShutdown() (bool, error) {
-	resp, err := u.nutClient.SendCommand(fmt.Sprintf("FSD %s", u.Name))
+	return u.ForceShutdownContext(context.Background())
+}
+
+// ForceShutdownContext sets the FSD flag on the UPS, honoring ctx. See ForceShutdown for details.
+func (u *UPS) ForceShutdownContext(ctx context.Context) (bool, error) {
+	resp, err := u.nutClient.SendCommandContext(ctx, fmt.Sprintf("FSD %s", u.Name))
 	if err != nil {
 		return false, err
 	}